@@ -18,6 +18,7 @@ package area
 import (
 	"fmt"
 	"image"
+	"sort"
 
 	"github.com/mum4k/termdash/internal/numbers"
 )
@@ -78,6 +79,188 @@ func VSplit(area image.Rectangle, widthPerc int) (left image.Rectangle, right im
 	return left, right, nil
 }
 
+// HSplitWeighted splits the provided area horizontally into len(weights)
+// areas stacked top to bottom, whose heights are proportional to the
+// provided weights. Any cells lost to integer rounding are distributed to
+// the areas whose exact share had the largest fractional remainder, so the
+// returned areas' heights always sum to area.Dy().
+// The weights must all be positive integers and there must be at least one
+// of them.
+func HSplitWeighted(area image.Rectangle, weights []int) ([]image.Rectangle, error) {
+	heights, err := distribute(area.Dy(), weights)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]image.Rectangle, len(heights))
+	y := area.Min.Y
+	for i, h := range heights {
+		areas[i] = image.Rect(area.Min.X, y, area.Max.X, y+h)
+		y += h
+	}
+	return areas, nil
+}
+
+// VSplitWeighted splits the provided area vertically into len(weights)
+// areas arranged left to right, whose widths are proportional to the
+// provided weights.
+// See HSplitWeighted for the rounding and validation behavior.
+func VSplitWeighted(area image.Rectangle, weights []int) ([]image.Rectangle, error) {
+	widths, err := distribute(area.Dx(), weights)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]image.Rectangle, len(widths))
+	x := area.Min.X
+	for i, w := range widths {
+		areas[i] = image.Rect(x, area.Min.Y, x+w, area.Max.Y)
+		x += w
+	}
+	return areas, nil
+}
+
+// distribute splits total cells into len(weights) non-negative parts
+// proportional to weights, using the largest remainder method so the parts
+// always sum to exactly total. The weights must all be positive integers.
+func distribute(total int, weights []int) ([]int, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("weights must not be empty")
+	}
+
+	sum := 0
+	for _, w := range weights {
+		if w <= 0 {
+			return nil, fmt.Errorf("invalid weight %d, weights must be positive integers", w)
+		}
+		sum += w
+	}
+
+	parts := make([]int, len(weights))
+	remainders := make([]float64, len(weights))
+	allocated := 0
+	for i, w := range weights {
+		exact := float64(total) * float64(w) / float64(sum)
+		parts[i] = int(exact)
+		remainders[i] = exact - float64(parts[i])
+		allocated += parts[i]
+	}
+
+	// The truncation above can only ever lose fewer cells than there are
+	// weights, so hand the leftover cells one at a time to the buckets
+	// whose exact share had the largest fractional remainder.
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for leftover := total - allocated; leftover > 0; leftover-- {
+		parts[order[leftover-1]]++
+	}
+	return parts, nil
+}
+
+// CellSize is either a fixed number of cells or, when created by Flex, a
+// weighted share of whatever space is left over, see Flex's documentation.
+type CellSize int
+
+// Flex returns a CellSize for use with HSplitCells or VSplitCells that, instead
+// of a fixed number of cells, claims a share of the space left over once
+// all the fixed CellSize entries have been subtracted, proportional to weight
+// among the other Flex entries in the same call.
+// The weight must be a positive integer.
+func Flex(weight int) CellSize {
+	return CellSize(-weight)
+}
+
+// isFlex reports whether s was created by Flex, returning its weight.
+func (s CellSize) isFlex() (weight int, ok bool) {
+	if s < 0 {
+		return int(-s), true
+	}
+	return 0, false
+}
+
+// HSplitCells splits the provided area horizontally, top to bottom,
+// according to sizes. Each element is either a fixed number of cells or, if
+// created via Flex, a share of the space remaining after all the fixed
+// sizes were subtracted, proportional to its weight among the other Flex
+// entries.
+// The fixed sizes must not add up to more cells than area.Dy().
+func HSplitCells(area image.Rectangle, sizes []CellSize) ([]image.Rectangle, error) {
+	heights, err := resolveCellSizes(area.Dy(), sizes)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]image.Rectangle, len(heights))
+	y := area.Min.Y
+	for i, h := range heights {
+		areas[i] = image.Rect(area.Min.X, y, area.Max.X, y+h)
+		y += h
+	}
+	return areas, nil
+}
+
+// VSplitCells splits the provided area vertically, left to right, according
+// to sizes.
+// See HSplitCells for the meaning of sizes and the validation behavior.
+func VSplitCells(area image.Rectangle, sizes []CellSize) ([]image.Rectangle, error) {
+	widths, err := resolveCellSizes(area.Dx(), sizes)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]image.Rectangle, len(widths))
+	x := area.Min.X
+	for i, w := range widths {
+		areas[i] = image.Rect(x, area.Min.Y, x+w, area.Max.Y)
+		x += w
+	}
+	return areas, nil
+}
+
+// resolveCellSizes converts sizes into a concrete number of cells per entry,
+// subtracting the fixed entries from total first and dividing what remains
+// between the Flex entries proportional to their weight.
+func resolveCellSizes(total int, sizes []CellSize) ([]int, error) {
+	if len(sizes) == 0 {
+		return nil, fmt.Errorf("sizes must not be empty")
+	}
+
+	cells := make([]int, len(sizes))
+	var flexIdx, flexWeights []int
+	fixed := 0
+	for i, s := range sizes {
+		if w, ok := s.isFlex(); ok {
+			flexIdx = append(flexIdx, i)
+			flexWeights = append(flexWeights, w)
+			continue
+		}
+		if s < 0 {
+			return nil, fmt.Errorf("invalid fixed size %d, must be zero or a positive integer", s)
+		}
+		cells[i] = int(s)
+		fixed += int(s)
+	}
+	if fixed > total {
+		return nil, fmt.Errorf("fixed sizes add up to %d cells, which doesn't fit in the available %d cells", fixed, total)
+	}
+
+	if len(flexWeights) > 0 {
+		flexCells, err := distribute(total-fixed, flexWeights)
+		if err != nil {
+			return nil, err
+		}
+		for i, idx := range flexIdx {
+			cells[idx] = flexCells[i]
+		}
+	}
+	return cells, nil
+}
+
 // ExcludeBorder returns a new area created by subtracting a border around the
 // provided area. Return the zero area if there isn't enough space to exclude
 // the border.
@@ -0,0 +1,228 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package area
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		desc    string
+		total   int
+		weights []int
+		want    []int
+		wantErr bool
+	}{
+		{
+			desc:    "no weights is an error",
+			total:   10,
+			weights: nil,
+			wantErr: true,
+		},
+		{
+			desc:    "a zero weight is an error",
+			total:   10,
+			weights: []int{1, 0},
+			wantErr: true,
+		},
+		{
+			desc:    "a negative weight is an error",
+			total:   10,
+			weights: []int{1, -1},
+			wantErr: true,
+		},
+		{
+			desc:    "evenly divisible weights",
+			total:   10,
+			weights: []int{1, 1},
+			want:    []int{5, 5},
+		},
+		{
+			desc:    "remainder goes to the largest fractional share",
+			total:   10,
+			weights: []int{1, 1, 1},
+			want:    []int{4, 3, 3},
+		},
+		{
+			desc:    "unequal weights",
+			total:   100,
+			weights: []int{1, 2, 3, 4},
+			want:    []int{10, 20, 30, 40},
+		},
+		{
+			desc:    "total always fully distributed",
+			total:   7,
+			weights: []int{1, 1, 1, 1, 1, 1, 1},
+			want:    []int{1, 1, 1, 1, 1, 1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := distribute(tc.total, tc.weights)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("distribute(%v, %v) => error %v, wantErr %v", tc.total, tc.weights, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			sum := 0
+			for _, p := range got {
+				sum += p
+			}
+			if sum != tc.total {
+				t.Errorf("distribute(%v, %v) = %v, which sums to %d, want %d", tc.total, tc.weights, got, sum, tc.total)
+			}
+			if tc.want != nil && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("distribute(%v, %v) = %v, want %v", tc.total, tc.weights, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHSplitWeighted(t *testing.T) {
+	area := image.Rect(0, 0, 10, 10)
+
+	got, err := HSplitWeighted(area, []int{1, 1})
+	if err != nil {
+		t.Fatalf("HSplitWeighted => unexpected error: %v", err)
+	}
+
+	want := []image.Rectangle{
+		image.Rect(0, 0, 10, 5),
+		image.Rect(0, 5, 10, 10),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HSplitWeighted(%v, [1, 1]) = %v, want %v", area, got, want)
+	}
+}
+
+func TestVSplitWeighted(t *testing.T) {
+	area := image.Rect(0, 0, 10, 10)
+
+	got, err := VSplitWeighted(area, []int{3, 1})
+	if err != nil {
+		t.Fatalf("VSplitWeighted => unexpected error: %v", err)
+	}
+
+	want := []image.Rectangle{
+		image.Rect(0, 0, 8, 10),
+		image.Rect(8, 0, 10, 10),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VSplitWeighted(%v, [3, 1]) = %v, want %v", area, got, want)
+	}
+}
+
+func TestHSplitCells(t *testing.T) {
+	tests := []struct {
+		desc    string
+		area    image.Rectangle
+		sizes   []CellSize
+		want    []image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc:    "no sizes is an error",
+			area:    image.Rect(0, 0, 10, 10),
+			sizes:   nil,
+			wantErr: true,
+		},
+		{
+			desc:    "fixed sizes exceed the area",
+			area:    image.Rect(0, 0, 10, 10),
+			sizes:   []CellSize{6, 6},
+			wantErr: true,
+		},
+		{
+			desc:  "all fixed",
+			area:  image.Rect(0, 0, 10, 10),
+			sizes: []CellSize{3, 7},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 10, 3),
+				image.Rect(0, 3, 10, 10),
+			},
+		},
+		{
+			desc:  "a single Flex claims everything left",
+			area:  image.Rect(0, 0, 10, 10),
+			sizes: []CellSize{2, Flex(1)},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 10, 2),
+				image.Rect(0, 2, 10, 10),
+			},
+		},
+		{
+			desc:  "multiple Flex entries split proportionally",
+			area:  image.Rect(0, 0, 10, 9),
+			sizes: []CellSize{3, Flex(1), Flex(2)},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 10, 3),
+				image.Rect(0, 3, 10, 5),
+				image.Rect(0, 5, 10, 9),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := HSplitCells(tc.area, tc.sizes)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("HSplitCells(%v, %v) => error %v, wantErr %v", tc.area, tc.sizes, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("HSplitCells(%v, %v) = %v, want %v", tc.area, tc.sizes, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVSplitCells(t *testing.T) {
+	area := image.Rect(0, 0, 10, 10)
+
+	got, err := VSplitCells(area, []CellSize{4, Flex(1), Flex(1)})
+	if err != nil {
+		t.Fatalf("VSplitCells => unexpected error: %v", err)
+	}
+
+	want := []image.Rectangle{
+		image.Rect(0, 0, 4, 10),
+		image.Rect(4, 0, 7, 10),
+		image.Rect(7, 0, 10, 10),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VSplitCells(%v, ...) = %v, want %v", area, got, want)
+	}
+}
+
+func TestFlex(t *testing.T) {
+	f := Flex(3)
+	weight, ok := f.isFlex()
+	if !ok || weight != 3 {
+		t.Errorf("Flex(3).isFlex() = %v, %v, want 3, true", weight, ok)
+	}
+
+	fixed := CellSize(3)
+	if weight, ok := fixed.isFlex(); ok {
+		t.Errorf("CellSize(3).isFlex() = %v, %v, want ok == false", weight, ok)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"image/color"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// floydSteinbergWeights are the fractions of the quantization error
+// distributed to the unprocessed neighbors of the pixel currently being
+// dithered, in (dx, dy, weight/16) form.
+var floydSteinbergWeights = [4]struct {
+	dx, dy int
+	weight float64
+}{
+	{1, 0, 7.0 / 16.0},  // right
+	{-1, 1, 3.0 / 16.0}, // bottom-left
+	{0, 1, 5.0 / 16.0},  // bottom
+	{1, 1, 1.0 / 16.0},  // bottom-right
+}
+
+// quantize quantizes grid in place to the palette selected by q, optionally
+// diffusing the quantization error with Floyd-Steinberg dithering. Returns a
+// grid of the cell.Color to draw for each pixel.
+//
+// If q is nil, the grid isn't quantized and every pixel keeps its original
+// true color.
+func quantize(grid [][]color.RGBA, q *quantizer, dithering Dithering) [][]cell.Color {
+	rows := len(grid)
+	out := make([][]cell.Color, rows)
+	if rows == 0 {
+		return out
+	}
+	cols := len(grid[0])
+	for y := range out {
+		out[y] = make([]cell.Color, cols)
+	}
+
+	if q == nil {
+		for y := 0; y < rows; y++ {
+			for x := 0; x < cols; x++ {
+				c := grid[y][x]
+				out[y][x] = cell.ColorRGB24(int(c.R), int(c.G), int(c.B))
+			}
+		}
+		return out
+	}
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			old := grid[y][x]
+			entry := q.nearest(old)
+			out[y][x] = entry.c
+
+			if dithering != DitheringFloydSteinberg {
+				continue
+			}
+			errR := int(old.R) - int(entry.rgb.R)
+			errG := int(old.G) - int(entry.rgb.G)
+			errB := int(old.B) - int(entry.rgb.B)
+			for _, w := range floydSteinbergWeights {
+				nx, ny := x+w.dx, y+w.dy
+				if nx < 0 || nx >= cols || ny < 0 || ny >= rows {
+					continue
+				}
+				n := grid[ny][nx]
+				grid[ny][nx] = color.RGBA{
+					R: clampChannel(float64(n.R) + float64(errR)*w.weight),
+					G: clampChannel(float64(n.G) + float64(errG)*w.weight),
+					B: clampChannel(float64(n.B) + float64(errB)*w.weight),
+					A: 0xff,
+				}
+			}
+		}
+	}
+	return out
+}
+
+// clampChannel clamps a diffused color channel value to a valid uint8.
+func clampChannel(v float64) uint8 {
+	return uint8(clampFloat(v, 0, 255))
+}
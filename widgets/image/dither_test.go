@@ -0,0 +1,119 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestQuantizeNoQuantizer(t *testing.T) {
+	grid := [][]color.RGBA{
+		{{0x11, 0x22, 0x33, 0xff}, {0xaa, 0xbb, 0xcc, 0xff}},
+	}
+
+	got := quantize(grid, nil, DitheringNone)
+
+	want := [][]cell.Color{
+		{cell.ColorRGB24(0x11, 0x22, 0x33), cell.ColorRGB24(0xaa, 0xbb, 0xcc)},
+	}
+	for y := range want {
+		for x := range want[y] {
+			if got[y][x] != want[y][x] {
+				t.Errorf("quantize()[%d][%d] = %v, want %v", y, x, got[y][x], want[y][x])
+			}
+		}
+	}
+}
+
+func TestQuantizeWithPalette(t *testing.T) {
+	tests := []struct {
+		desc      string
+		dithering Dithering
+	}{
+		{
+			desc:      "no dithering",
+			dithering: DitheringNone,
+		},
+		{
+			desc:      "Floyd-Steinberg dithering",
+			dithering: DitheringFloydSteinberg,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			// A single exact bright-red pixel and a near-bright-red pixel,
+			// quantized down to the 16-color palette. Both should map to the
+			// bright red palette entry, cell.ColorNumber(9) (buildPalette16
+			// assigns plain cell.ColorRed to the dim red {0x80, 0, 0} entry
+			// instead), whether or not the (otherwise lossless) quantization
+			// error is diffused.
+			grid := [][]color.RGBA{
+				{{0xff, 0, 0, 0xff}, {0xf8, 0x08, 0x08, 0xff}},
+			}
+			q := newQuantizer(Color16)
+			want := cell.ColorNumber(9)
+
+			got := quantize(grid, q, tc.dithering)
+
+			for x, c := range got[0] {
+				if c != want {
+					t.Errorf("quantize()[0][%d] = %v, want %v", x, c, want)
+				}
+			}
+		})
+	}
+}
+
+func TestQuantizeDiffusesError(t *testing.T) {
+	// A color exactly halfway between black and red. Quantizing it alone
+	// rounds to one or the other; with dithering the leftover error is
+	// pushed onto the neighboring pixel, nudging its quantized result.
+	grid := [][]color.RGBA{
+		{{0x80, 0, 0, 0xff}, {0x80, 0, 0, 0xff}},
+	}
+	q := newQuantizer(Color16)
+
+	got := quantize(grid, q, DitheringFloydSteinberg)
+
+	for x, c := range got[0] {
+		if c != cell.ColorRed && c != cell.ColorBlack {
+			t.Errorf("quantize()[0][%d] = %v, want cell.ColorRed or cell.ColorBlack", x, c)
+		}
+	}
+}
+
+func TestClampChannel(t *testing.T) {
+	tests := []struct {
+		desc string
+		v    float64
+		want uint8
+	}{
+		{desc: "within range", v: 128, want: 128},
+		{desc: "below zero", v: -10, want: 0},
+		{desc: "above 255", v: 300, want: 255},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := clampChannel(tc.v); got != tc.want {
+				t.Errorf("clampChannel(%v) = %v, want %v", tc.v, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package image contains a widget that draws a static image.
+//
+// The widget doubles the vertical resolution of the terminal by drawing the
+// Unicode upper half block character (▀) in every cell, using the
+// foreground color for the pixel that falls on the top half of the cell and
+// the background color for the pixel that falls on the bottom half. This
+// lets the widget display roughly cols*2*rows pixels in a canvas that is
+// only cols by rows cells large.
+package image
@@ -0,0 +1,138 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// image.go defines the Image widget.
+
+package image
+
+import (
+	"errors"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// halfBlock is the Unicode upper half block character used to draw two
+// pixels per terminal cell, the top one in the foreground color and the
+// bottom one in the background color.
+const halfBlock = '▀'
+
+// Image is a widget that draws a static image loaded from the standard
+// library's image package, e.g. via image/png or image/jpeg.
+//
+// Image is wrapped in a mutex and is thread-safe.
+type Image struct {
+	mu sync.Mutex
+
+	// img is the image currently configured for drawing.
+	img image.Image
+
+	// opts are the provided options.
+	opts *options
+}
+
+// NewFromImage returns a new Image widget that draws the provided image.
+func NewFromImage(img image.Image, opts ...Option) (*Image, error) {
+	i := &Image{
+		opts: newOptions(),
+	}
+	for _, opt := range opts {
+		opt.set(i.opts)
+	}
+	if err := i.opts.validate(); err != nil {
+		return nil, err
+	}
+	if err := i.SetImage(img); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// SetImage updates the image that will be drawn on the next call to Draw.
+func (i *Image) SetImage(img image.Image) error {
+	if img == nil {
+		return errors.New("the image must not be nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.img = img
+	return nil
+}
+
+// Draw draws the configured image onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (i *Image) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.img == nil {
+		return nil
+	}
+
+	ar := cvs.Area()
+	cols := ar.Dx()
+	rows := ar.Dy()
+	if cols == 0 || rows == 0 {
+		return nil
+	}
+	// Each cell draws two pixels stacked vertically.
+	pixRows := 2 * rows
+
+	grid := resample(i.img, cols, pixRows, i.opts.background)
+	q := newQuantizer(i.opts.colorMode)
+	colors := quantize(grid, q, i.opts.dithering)
+
+	for row := 0; row < rows; row++ {
+		top := colors[2*row]
+		bottom := colors[2*row+1]
+		for col := 0; col < cols; col++ {
+			if _, err := cvs.SetCell(
+				image.Point{col, row},
+				halfBlock,
+				cell.FgColor(top[col]),
+				cell.BgColor(bottom[col]),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+// The Image widget doesn't support keyboard events.
+func (*Image) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	return errors.New("the Image widget doesn't support keyboard events")
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+// The Image widget doesn't support mouse events.
+func (*Image) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	return errors.New("the Image widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*Image) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
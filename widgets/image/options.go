@@ -0,0 +1,148 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Option is used to provide options to NewFromImage or SetImage.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the provided options.
+type options struct {
+	dithering  Dithering
+	colorMode  ColorMode
+	background color.RGBA
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		dithering:  DitheringNone,
+		colorMode:  ColorTrueColor,
+		background: color.RGBA{0, 0, 0, 0xff},
+	}
+}
+
+// Dithering indicates the dithering algorithm used when the widget quantizes
+// pixel colors down to the palette selected by the configured ColorMode.
+type Dithering int
+
+// String implements fmt.Stringer()
+func (d Dithering) String() string {
+	if n, ok := ditheringNames[d]; ok {
+		return n
+	}
+	return "DitheringUnknown"
+}
+
+// ditheringNames maps Dithering values to human readable names.
+var ditheringNames = map[Dithering]string{
+	DitheringNone:           "DitheringNone",
+	DitheringFloydSteinberg: "DitheringFloydSteinberg",
+}
+
+const (
+	// DitheringNone performs no dithering, each pixel is independently
+	// quantized to the nearest color in the configured palette.
+	DitheringNone Dithering = iota
+
+	// DitheringFloydSteinberg diffuses the quantization error of each pixel
+	// onto its unprocessed neighbors, which reduces the visible color
+	// banding that plain quantization introduces on palettes smaller than
+	// true color.
+	DitheringFloydSteinberg
+)
+
+// WithDithering sets the dithering algorithm applied when the ColorMode
+// quantizes pixels to a palette smaller than the source image.
+// Defaults to DitheringNone.
+func WithDithering(d Dithering) Option {
+	return option(func(opts *options) {
+		opts.dithering = d
+	})
+}
+
+// ColorMode indicates the color palette the widget quantizes pixels to
+// before drawing them onto the canvas.
+type ColorMode int
+
+// String implements fmt.Stringer()
+func (cm ColorMode) String() string {
+	if n, ok := colorModeNames[cm]; ok {
+		return n
+	}
+	return "ColorModeUnknown"
+}
+
+// colorModeNames maps ColorMode values to human readable names.
+var colorModeNames = map[ColorMode]string{
+	ColorTrueColor: "ColorTrueColor",
+	Color256:       "Color256",
+	Color16:        "Color16",
+}
+
+const (
+	// ColorTrueColor draws pixels using their original 24-bit RGB color.
+	// Requires a terminal that supports true color.
+	ColorTrueColor ColorMode = iota
+
+	// Color256 quantizes pixels to the xterm 256-color palette.
+	Color256
+
+	// Color16 quantizes pixels to the ANSI 16-color palette.
+	Color16
+)
+
+// WithColorMode sets the color palette used to draw the image.
+// Defaults to ColorTrueColor.
+func WithColorMode(cm ColorMode) Option {
+	return option(func(opts *options) {
+		opts.colorMode = cm
+	})
+}
+
+// WithBackgroundColor sets the color used in place of transparent pixels in
+// the source image.
+// Defaults to black.
+func WithBackgroundColor(c color.Color) Option {
+	return option(func(opts *options) {
+		opts.background = color.RGBAModel.Convert(c).(color.RGBA)
+	})
+}
+
+// validate returns an error if the options aren't valid.
+func (o *options) validate() error {
+	if _, ok := ditheringNames[o.dithering]; !ok {
+		return fmt.Errorf("invalid Dithering(%d)", o.dithering)
+	}
+	if _, ok := colorModeNames[o.colorMode]; !ok {
+		return fmt.Errorf("invalid ColorMode(%d)", o.colorMode)
+	}
+	return nil
+}
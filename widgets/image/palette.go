@@ -0,0 +1,151 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// palEntry is one color available in a quantized palette.
+type palEntry struct {
+	// rgb is the color as drawn on the canvas.
+	rgb color.RGBA
+	// c is the cell.Color to use to draw rgb.
+	c cell.Color
+}
+
+// palette256 is the xterm 256-color palette, built once on first use.
+var palette256 = buildPalette256()
+
+// palette16 is the ANSI 16-color palette.
+var palette16 = buildPalette16()
+
+// buildPalette16 returns the 16 ANSI colors paired with their approximate
+// RGB values, in the same order as the cell.ColorBlack..cell.ColorWhite
+// constants.
+func buildPalette16() []palEntry {
+	// The approximate RGB values of the standard ANSI 16 colors as rendered
+	// by most terminal emulators.
+	rgb := [16]color.RGBA{
+		{0, 0, 0, 0xff},       {0x80, 0, 0, 0xff},    {0, 0x80, 0, 0xff},    {0x80, 0x80, 0, 0xff},
+		{0, 0, 0x80, 0xff},    {0x80, 0, 0x80, 0xff}, {0, 0x80, 0x80, 0xff}, {0xc0, 0xc0, 0xc0, 0xff},
+		{0x80, 0x80, 0x80, 0xff}, {0xff, 0, 0, 0xff}, {0, 0xff, 0, 0xff},    {0xff, 0xff, 0, 0xff},
+		{0, 0, 0xff, 0xff},    {0xff, 0, 0xff, 0xff}, {0, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+	}
+	colors := [16]cell.Color{
+		cell.ColorBlack, cell.ColorRed, cell.ColorGreen, cell.ColorYellow,
+		cell.ColorBlue, cell.ColorMagenta, cell.ColorCyan, cell.ColorWhite,
+		cell.ColorNumber(8), cell.ColorNumber(9), cell.ColorNumber(10), cell.ColorNumber(11),
+		cell.ColorNumber(12), cell.ColorNumber(13), cell.ColorNumber(14), cell.ColorNumber(15),
+	}
+	pal := make([]palEntry, 0, len(rgb))
+	for i, c := range rgb {
+		pal = append(pal, palEntry{rgb: c, c: colors[i]})
+	}
+	return pal
+}
+
+// buildPalette256 returns the xterm 256-color palette, i.e. the 16 ANSI
+// colors, the 6x6x6 RGB color cube and the 24 step grayscale ramp.
+func buildPalette256() []palEntry {
+	pal := make([]palEntry, 0, 256)
+	pal = append(pal, palette16...)
+
+	// Colors 16-231 are the 6x6x6 RGB color cube, each channel taking one of
+	// the six steps below.
+	steps := [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				n := 16 + 36*r + 6*g + b
+				pal = append(pal, palEntry{
+					rgb: color.RGBA{steps[r], steps[g], steps[b], 0xff},
+					c:   cell.ColorNumber(n),
+				})
+			}
+		}
+	}
+
+	// Colors 232-255 are a 24 step grayscale ramp.
+	for i := 0; i < 24; i++ {
+		v := uint8(8 + i*10)
+		n := 232 + i
+		pal = append(pal, palEntry{
+			rgb: color.RGBA{v, v, v, 0xff},
+			c:   cell.ColorNumber(n),
+		})
+	}
+	return pal
+}
+
+// quantizer finds the palette color nearest to a requested RGB color and
+// memoizes the result, since the same handful of source colors are looked
+// up repeatedly while drawing an image.
+type quantizer struct {
+	pal   []palEntry
+	mu    sync.Mutex
+	cache map[color.RGBA]palEntry
+}
+
+// newQuantizer returns a quantizer for the palette of the given ColorMode.
+// Returns nil for ColorTrueColor, which doesn't quantize.
+func newQuantizer(cm ColorMode) *quantizer {
+	var pal []palEntry
+	switch cm {
+	case Color256:
+		pal = palette256
+	case Color16:
+		pal = palette16
+	default:
+		return nil
+	}
+	return &quantizer{
+		pal:   pal,
+		cache: map[color.RGBA]palEntry{},
+	}
+}
+
+// nearest returns the palette entry closest to rgb in the squared Euclidean
+// RGB distance. The result is memoized since the palette lookup is the hot
+// path when quantizing an image.
+func (q *quantizer) nearest(rgb color.RGBA) palEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if e, ok := q.cache[rgb]; ok {
+		return e
+	}
+
+	best := q.pal[0]
+	bestDist := sqDist(rgb, best.rgb)
+	for _, e := range q.pal[1:] {
+		if d := sqDist(rgb, e.rgb); d < bestDist {
+			best, bestDist = e, d
+		}
+	}
+	q.cache[rgb] = best
+	return best
+}
+
+// sqDist returns the squared Euclidean distance between two RGB colors.
+func sqDist(a, b color.RGBA) int {
+	dr := int(a.R) - int(b.R)
+	dg := int(a.G) - int(b.G)
+	db := int(a.B) - int(b.B)
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"image"
+	"image/color"
+)
+
+// resample resamples src into a grid of cols by rows RGBA pixels using
+// bilinear interpolation. Transparent areas of src are blended onto bg.
+func resample(src image.Image, cols, rows int, bg color.RGBA) [][]color.RGBA {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+
+	grid := make([][]color.RGBA, rows)
+	for y := 0; y < rows; y++ {
+		grid[y] = make([]color.RGBA, cols)
+		// Source Y coordinate of the center of destination pixel y.
+		sy := (float64(y)+0.5)*float64(sh)/float64(rows) - 0.5
+		for x := 0; x < cols; x++ {
+			sx := (float64(x)+0.5)*float64(sw)/float64(cols) - 0.5
+			grid[y][x] = bilinear(src, sb, sx, sy, bg)
+		}
+	}
+	return grid
+}
+
+// bilinear returns the color at the fractional source coordinates (sx, sy),
+// interpolated from the four nearest pixels in src.
+func bilinear(src image.Image, b image.Rectangle, sx, sy float64, bg color.RGBA) color.RGBA {
+	x0 := floor(sx)
+	y0 := floor(sy)
+	fx := sx - float64(x0)
+	fy := sy - float64(y0)
+
+	c00 := sampleAt(src, b, x0, y0, bg)
+	c10 := sampleAt(src, b, x0+1, y0, bg)
+	c01 := sampleAt(src, b, x0, y0+1, bg)
+	c11 := sampleAt(src, b, x0+1, y0+1, bg)
+
+	return color.RGBA{
+		R: lerp2D(c00.R, c10.R, c01.R, c11.R, fx, fy),
+		G: lerp2D(c00.G, c10.G, c01.G, c11.G, fx, fy),
+		B: lerp2D(c00.B, c10.B, c01.B, c11.B, fx, fy),
+		A: 0xff,
+	}
+}
+
+// sampleAt returns the color of src at (x, y), clamped to its bounds, with
+// any transparency alpha-blended onto bg.
+func sampleAt(src image.Image, b image.Rectangle, x, y int, bg color.RGBA) color.RGBA {
+	x = clampInt(x, b.Min.X, b.Max.X-1)
+	y = clampInt(y, b.Min.Y, b.Max.Y-1)
+
+	r, g, bch, a := src.At(x, y).RGBA()
+	if a == 0 {
+		return bg
+	}
+	// image.Color.RGBA returns alpha-premultiplied 16-bit components, so
+	// un-premultiply before blending the partially transparent pixel onto
+	// the configured background color.
+	af := float64(a) / 0xffff
+	return color.RGBA{
+		R: blendChannel(float64(r)/0xffff/af, bg.R, af),
+		G: blendChannel(float64(g)/0xffff/af, bg.G, af),
+		B: blendChannel(float64(bch)/0xffff/af, bg.B, af),
+		A: 0xff,
+	}
+}
+
+// blendChannel alpha-blends a foreground channel (in the 0..1 range) with a
+// background channel (in the 0..255 range).
+func blendChannel(fg float64, bg uint8, alpha float64) uint8 {
+	v := fg*255*alpha + float64(bg)*(1-alpha)
+	return uint8(clampFloat(v, 0, 255))
+}
+
+// lerp2D bilinearly interpolates between four corner values.
+func lerp2D(c00, c10, c01, c11 uint8, fx, fy float64) uint8 {
+	top := lerp(float64(c00), float64(c10), fx)
+	bottom := lerp(float64(c01), float64(c11), fx)
+	return uint8(clampFloat(lerp(top, bottom, fy), 0, 255))
+}
+
+// lerp linearly interpolates between a and b by t in the range [0,1].
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// floor returns the largest integer not greater than v.
+func floor(v float64) int {
+	i := int(v)
+	if v < 0 && float64(i) != v {
+		i--
+	}
+	return i
+}
+
+// clampInt clamps v to the range [lo, hi].
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// clampFloat clamps v to the range [lo, hi].
+func clampFloat(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
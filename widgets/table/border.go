@@ -0,0 +1,243 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// border.go defines the per-side cell border configuration and the logic
+// that merges adjacent cells' borders into the box-drawing gridlines drawn
+// between them.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// Border is a bitmask of the sides of a cell that should be bordered.
+type Border int
+
+// Sides of a cell that can individually be bordered.
+const (
+	BorderTop Border = 1 << iota
+	BorderRight
+	BorderBottom
+	BorderLeft
+)
+
+// BorderNone draws no border on any side.
+const BorderNone Border = 0
+
+// BorderAll draws a border on all four sides.
+const BorderAll = BorderTop | BorderRight | BorderBottom | BorderLeft
+
+// CellBorders configures the per-side borders drawn around the cell.
+// This is a hierarchical option, it overrides the one provided at Content
+// or Row level. Cell is the most specific level at which borders can be
+// configured.
+func CellBorders(mask Border, opts ...cell.Option) CellOption {
+	return cellOption(func(c *Cell) {
+		c.hierarchical.borders = &mask
+		c.hierarchical.borderOpts = opts
+	})
+}
+
+// junctionRunes maps a 4-bit mask of the lines incident on a grid
+// intersection to the box-drawing rune that should be drawn there.
+// Bit 0 is a line going up, bit 1 right, bit 2 down and bit 3 left.
+var junctionRunes = map[uint8]rune{
+	0: ' ',
+
+	0b0001: '│', // up only
+	0b0100: '│', // down only
+	0b0101: '│', // up + down
+
+	0b0010: '─', // right only
+	0b1000: '─', // left only
+	0b1010: '─', // left + right
+
+	0b0011: '└', // up + right
+	0b0110: '┌', // right + down
+	0b1100: '┐', // down + left
+	0b1001: '┘', // left + up
+
+	0b0111: '├', // up + right + down
+	0b1011: '┴', // up + right + left
+	0b1101: '┤', // up + down + left
+	0b1110: '┬', // right + down + left
+
+	0b1111: '┼', // all four
+}
+
+// junctionRune returns the box-drawing rune for the given 4-bit mask of
+// lines incident on a grid intersection, as described by junctionRunes.
+func junctionRune(mask uint8) rune {
+	return junctionRunes[mask]
+}
+
+// borderCell describes one logical table cell's position, span and
+// configured Border for the purpose of computing where gridlines between
+// cells should be drawn.
+type borderCell struct {
+	// row and col are the zero-based grid coordinates of the cell's
+	// top-left corner.
+	row, col int
+	// rowSpan and colSpan are the number of grid rows/columns the cell
+	// occupies, both at least 1.
+	rowSpan, colSpan int
+	// borders are the sides of the cell that should be bordered.
+	borders Border
+}
+
+// cellOwners returns the rows x cols grid of indexes into cells, recording
+// which borderCell owns each grid position. Positions not covered by any
+// cell are set to -1.
+func cellOwners(rows, cols int, cells []borderCell) [][]int {
+	owner := make([][]int, rows)
+	for r := range owner {
+		owner[r] = make([]int, cols)
+		for c := range owner[r] {
+			owner[r][c] = -1
+		}
+	}
+	for i, bc := range cells {
+		for r := bc.row; r < bc.row+bc.rowSpan && r < rows; r++ {
+			for c := bc.col; c < bc.col+bc.colSpan && c < cols; c++ {
+				owner[r][c] = i
+			}
+		}
+	}
+	return owner
+}
+
+// hasRight reports whether the cell occupying grid position (r, c) wants a
+// border drawn on its right side, i.e. c is the rightmost column of its
+// span and its Border mask includes BorderRight.
+func hasRight(owner [][]int, cells []borderCell, r, c int) bool {
+	idx := owner[r][c]
+	if idx < 0 {
+		return false
+	}
+	bc := cells[idx]
+	return c == bc.col+bc.colSpan-1 && bc.borders&BorderRight != 0
+}
+
+// hasLeft reports whether the cell occupying grid position (r, c) wants a
+// border drawn on its left side.
+func hasLeft(owner [][]int, cells []borderCell, r, c int) bool {
+	idx := owner[r][c]
+	if idx < 0 {
+		return false
+	}
+	bc := cells[idx]
+	return c == bc.col && bc.borders&BorderLeft != 0
+}
+
+// hasTop reports whether the cell occupying grid position (r, c) wants a
+// border drawn on its top side.
+func hasTop(owner [][]int, cells []borderCell, r, c int) bool {
+	idx := owner[r][c]
+	if idx < 0 {
+		return false
+	}
+	bc := cells[idx]
+	return r == bc.row && bc.borders&BorderTop != 0
+}
+
+// hasBottom reports whether the cell occupying grid position (r, c) wants a
+// border drawn on its bottom side.
+func hasBottom(owner [][]int, cells []borderCell, r, c int) bool {
+	idx := owner[r][c]
+	if idx < 0 {
+		return false
+	}
+	bc := cells[idx]
+	return r == bc.row+bc.rowSpan-1 && bc.borders&BorderBottom != 0
+}
+
+// vEdge reports whether a vertical gridline segment is drawn in grid row r
+// at the boundary between columns c-1 and c (0 <= c <= cols). Because
+// hasRight/hasLeft only fire at the edge column of a cell's own span, a
+// boundary that falls strictly inside a colSpan never has either neighbor
+// claim it, which is what suppresses interior gridlines.
+func vEdge(owner [][]int, cells []borderCell, cols, r, c int) bool {
+	var right, left bool
+	if c > 0 {
+		right = hasRight(owner, cells, r, c-1)
+	}
+	if c < cols {
+		left = hasLeft(owner, cells, r, c)
+	}
+	return right || left
+}
+
+// hEdge reports whether a horizontal gridline segment is drawn in grid
+// column c at the boundary between rows r-1 and r (0 <= r <= rows). Spans
+// are suppressed the same way as in vEdge.
+func hEdge(owner [][]int, cells []borderCell, rows, r, c int) bool {
+	var bottom, top bool
+	if r > 0 {
+		bottom = hasBottom(owner, cells, r-1, c)
+	}
+	if r < rows {
+		top = hasTop(owner, cells, r, c)
+	}
+	return bottom || top
+}
+
+// intersectionMask computes the 4-bit mask (see junctionRunes) of the
+// gridlines incident on the grid intersection at (r, c), 0 <= r <= rows,
+// 0 <= c <= cols. It is the single place that decides whether a gridline
+// segment is present, shared by gridBorders and by render_grid.go's own
+// grid renderer so the two never compute this differently.
+func intersectionMask(owner [][]int, cells []borderCell, rows, cols, r, c int) uint8 {
+	var mask uint8
+	if r > 0 && vEdge(owner, cells, cols, r-1, c) {
+		mask |= 0b0001 // up
+	}
+	if c < cols && hEdge(owner, cells, rows, r, c) {
+		mask |= 0b0010 // right
+	}
+	if r < rows && vEdge(owner, cells, cols, r, c) {
+		mask |= 0b0100 // down
+	}
+	if c > 0 && hEdge(owner, cells, rows, r, c-1) {
+		mask |= 0b1000 // left
+	}
+	return mask
+}
+
+// gridBorders computes, for a rows x cols grid of cells, the box-drawing
+// rune to draw at every one of the (rows+1) x (cols+1) grid intersections.
+// Adjacent cells that each border their shared edge produce just one line
+// there, and gridlines that would fall inside a row or column span are
+// omitted.
+func gridBorders(rows, cols int, cells []borderCell) [][]rune {
+	return intersectionRunes(rows, cols, cells, true)
+}
+
+// intersectionRunes is the shared implementation behind gridBorders; unicode
+// selects between box-drawing and plain ASCII gridline characters. It's
+// exported to the rest of the package so render_grid.go's ASCII grid style
+// computes intersections exactly the same way gridBorders does, instead of
+// reimplementing the mask logic.
+func intersectionRunes(rows, cols int, cells []borderCell, unicode bool) [][]rune {
+	owner := cellOwners(rows, cols, cells)
+
+	runes := make([][]rune, rows+1)
+	for r := 0; r <= rows; r++ {
+		runes[r] = make([]rune, cols+1)
+		for c := 0; c <= cols; c++ {
+			runes[r][c] = gridRune(intersectionMask(owner, cells, rows, cols, r, c), unicode)
+		}
+	}
+	return runes
+}
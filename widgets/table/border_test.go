@@ -0,0 +1,174 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJunctionRune(t *testing.T) {
+	tests := []struct {
+		desc string
+		mask uint8
+		want rune
+	}{
+		{desc: "nothing incident", mask: 0, want: ' '},
+		{desc: "up and down", mask: 0b0101, want: '│'},
+		{desc: "left and right", mask: 0b1010, want: '─'},
+		{desc: "up and right, a corner", mask: 0b0011, want: '└'},
+		{desc: "all four sides", mask: 0b1111, want: '┼'},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := junctionRune(tc.mask); got != tc.want {
+				t.Errorf("junctionRune(%04b) = %q, want %q", tc.mask, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGridBorders(t *testing.T) {
+	tests := []struct {
+		desc  string
+		rows  int
+		cols  int
+		cells []borderCell
+		want  [][]rune
+	}{
+		{
+			desc: "single bordered cell draws a full box",
+			rows: 1,
+			cols: 1,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 1, colSpan: 1, borders: BorderAll},
+			},
+			want: [][]rune{
+				{'┌', '┐'},
+				{'└', '┘'},
+			},
+		},
+		{
+			desc: "two side-by-side cells share one vertical gridline",
+			rows: 1,
+			cols: 2,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 1, colSpan: 1, borders: BorderAll},
+				{row: 0, col: 1, rowSpan: 1, colSpan: 1, borders: BorderAll},
+			},
+			want: [][]rune{
+				{'┌', '┬', '┐'},
+				{'└', '┴', '┘'},
+			},
+		},
+		{
+			desc: "a column span suppresses the gridline inside it",
+			rows: 1,
+			cols: 2,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 1, colSpan: 2, borders: BorderAll},
+			},
+			want: [][]rune{
+				{'┌', '─', '┐'},
+				{'└', '─', '┘'},
+			},
+		},
+		{
+			desc: "a row span suppresses the gridline inside it",
+			rows: 2,
+			cols: 1,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 2, colSpan: 1, borders: BorderAll},
+			},
+			want: [][]rune{
+				{'┌', '┐'},
+				{'│', '│'},
+				{'└', '┘'},
+			},
+		},
+		{
+			desc: "BorderNone draws nothing",
+			rows: 1,
+			cols: 1,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 1, colSpan: 1, borders: BorderNone},
+			},
+			want: [][]rune{
+				{' ', ' '},
+				{' ', ' '},
+			},
+		},
+		{
+			desc: "only the shared side is drawn once when one neighbor opts out",
+			rows: 1,
+			cols: 2,
+			cells: []borderCell{
+				{row: 0, col: 0, rowSpan: 1, colSpan: 1, borders: BorderRight},
+				{row: 0, col: 1, rowSpan: 1, colSpan: 1, borders: BorderNone},
+			},
+			want: [][]rune{
+				{' ', '│', ' '},
+				{' ', '│', ' '},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := gridBorders(tc.rows, tc.cols, tc.cells)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("gridBorders(%d, %d, %v) = %v, want %v", tc.rows, tc.cols, tc.cells, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCellOwners(t *testing.T) {
+	cells := []borderCell{
+		{row: 0, col: 0, rowSpan: 1, colSpan: 2},
+		{row: 1, col: 0, rowSpan: 1, colSpan: 1},
+	}
+
+	got := cellOwners(2, 2, cells)
+
+	want := [][]int{
+		{0, 0},
+		{1, -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cellOwners(2, 2, %v) = %v, want %v", cells, got, want)
+	}
+}
+
+func TestResolveHierarchicalBorders(t *testing.T) {
+	contentBorders := BorderAll
+	cellBorders := BorderNone
+
+	content := &hierarchicalOptions{borders: &contentBorders}
+	cl := &hierarchicalOptions{borders: &cellBorders}
+
+	got := resolveHierarchical(content, nil, cl)
+	if got.borders != BorderNone {
+		t.Errorf("resolveHierarchical(content=BorderAll, cell=BorderNone).borders = %v, want BorderNone", got.borders)
+	}
+
+	// With nothing set at the cell level, the content level's value must
+	// still show through.
+	got = resolveHierarchical(content, nil, &hierarchicalOptions{})
+	if got.borders != BorderAll {
+		t.Errorf("resolveHierarchical(content=BorderAll, cell=unset).borders = %v, want BorderAll", got.borders)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// content.go defines Content, the table's data, and the hierarchical
+// options shared between Content, Row, Cell and Data.
+
+import (
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/wrap"
+)
+
+// hierarchicalOptions are the options that can be set at the Content, Row or
+// Cell level. Options set at a more specific level (further down the
+// Content -> Row -> Cell -> Data chain) override the ones set at a less
+// specific level.
+type hierarchicalOptions struct {
+	// cellOpts are the cell.Options applied to the cells that contain the
+	// content.
+	cellOpts []cell.Option
+	// height is the configured height of cells, unset means automatic.
+	height *int
+	// horizontalPadding is the configured horizontal padding, unset means
+	// zero.
+	horizontalPadding *int
+	// verticalPadding is the configured vertical padding, unset means zero.
+	verticalPadding *int
+	// alignHorizontal is the configured horizontal alignment of the
+	// content, unset means align.HorizontalLeft.
+	alignHorizontal *align.Horizontal
+	// alignVertical is the configured vertical alignment of the content,
+	// unset means align.VerticalTop.
+	alignVertical *align.Vertical
+	// wrapMode is the configured line wrapping mode, unset means
+	// wrap.Never.
+	wrapMode *wrap.Mode
+	// borders is the configured set of sides that should be bordered, unset
+	// means nothing was configured at this level. A pointer, like the other
+	// fields above, so that an explicit BorderNone at a more specific level
+	// can override a border inherited from a less specific one.
+	borders *Border
+	// borderOpts are the cell.Options applied when drawing borders.
+	borderOpts []cell.Option
+}
+
+// ContentOption is used to provide options to NewContent.
+type ContentOption interface {
+	// set sets the provided option.
+	set(*Content)
+}
+
+// contentOption implements ContentOption.
+type contentOption func(*Content)
+
+// set implements ContentOption.set.
+func (co contentOption) set(c *Content) {
+	co(c)
+}
+
+// ContentBorders configures the default per-side borders for all the cells
+// in the content.
+// This is a hierarchical option, it can be overridden at the Row or Cell
+// level.
+func ContentBorders(mask Border, opts ...cell.Option) ContentOption {
+	return contentOption(func(c *Content) {
+		c.hierarchical.borders = &mask
+		c.hierarchical.borderOpts = opts
+	})
+}
+
+// Content is the data table, a rectangular collection of Row instances.
+type Content struct {
+	// rows are the rows that make up the content, in display order.
+	rows []*Row
+
+	// hierarchical are the hierarchical options specified at the Content
+	// level.
+	hierarchical *hierarchicalOptions
+}
+
+// NewContent returns a new Content containing the provided rows.
+func NewContent(opts ...ContentOption) *Content {
+	c := &Content{
+		hierarchical: &hierarchicalOptions{},
+	}
+	for _, opt := range opts {
+		opt.set(c)
+	}
+	return c
+}
+
+// AddRows appends the provided rows to the content.
+func (c *Content) AddRows(rows ...*Row) *Content {
+	c.rows = append(c.rows, rows...)
+	return c
+}
+
+// resolved are the hierarchicalOptions after merging every level of the
+// Content -> Row -> Cell -> Data chain, with defaults substituted for
+// anything left unset.
+type resolved struct {
+	cellOpts          []cell.Option
+	height            int
+	horizontalPadding int
+	verticalPadding   int
+	alignHorizontal   align.Horizontal
+	alignVertical     align.Vertical
+	wrapMode          wrap.Mode
+	borders           Border
+	borderOpts        []cell.Option
+}
+
+// resolveHierarchical merges hierarchicalOptions from multiple levels of the
+// override chain, in order from least to most specific, e.g.
+// resolveHierarchical(content.hierarchical, row.hierarchical, cell.hierarchical).
+// A nil level is ignored, which allows callers to omit levels that don't
+// apply, e.g. when resolving options for a Cell that isn't inside a Row.
+func resolveHierarchical(levels ...*hierarchicalOptions) *resolved {
+	r := &resolved{
+		alignHorizontal: align.HorizontalLeft,
+		alignVertical:   align.VerticalTop,
+		wrapMode:        wrap.Never,
+	}
+	for _, h := range levels {
+		if h == nil {
+			continue
+		}
+		if h.cellOpts != nil {
+			r.cellOpts = h.cellOpts
+		}
+		if h.height != nil {
+			r.height = *h.height
+		}
+		if h.horizontalPadding != nil {
+			r.horizontalPadding = *h.horizontalPadding
+		}
+		if h.verticalPadding != nil {
+			r.verticalPadding = *h.verticalPadding
+		}
+		if h.alignHorizontal != nil {
+			r.alignHorizontal = *h.alignHorizontal
+		}
+		if h.alignVertical != nil {
+			r.alignVertical = *h.alignVertical
+		}
+		if h.wrapMode != nil {
+			r.wrapMode = *h.wrapMode
+		}
+		if h.borders != nil {
+			r.borders = *h.borders
+		}
+		if h.borderOpts != nil {
+			r.borderOpts = h.borderOpts
+		}
+	}
+	return r
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// content_data.go defines a type that represents a single piece of content
+// inside a Cell.
+
+// DataOption is used to provide options to NewDataWithOpts.
+type DataOption interface {
+	// set sets the provided option.
+	set(*Data)
+}
+
+// dataOption implements DataOption.
+type dataOption func(*Data)
+
+// set implements DataOption.set.
+func (do dataOption) set(d *Data) {
+	do(d)
+}
+
+// Data is a single piece of text content inside a Cell. A Cell can contain
+// multiple Data instances, each one of which can have its own cell.Option,
+// e.g. to highlight part of the cell's text in a different color.
+type Data struct {
+	// text is the content of this Data.
+	text string
+
+	// hierarchical are the hierarchical options specified at the Data
+	// level.
+	hierarchical *hierarchicalOptions
+}
+
+// String implements fmt.Stringer.
+func (d *Data) String() string {
+	return d.text
+}
+
+// NewData returns a new Data instance with the provided text.
+// If you need to apply options use NewDataWithOpts.
+func NewData(text string) *Data {
+	return NewDataWithOpts(text)
+}
+
+// NewDataWithOpts returns a new Data instance with the provided text and
+// options.
+func NewDataWithOpts(text string, opts ...DataOption) *Data {
+	d := &Data{
+		text:         text,
+		hierarchical: &hierarchicalOptions{},
+	}
+	for _, opt := range opts {
+		opt.set(d)
+	}
+	return d
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// content_row.go defines a type that represents a single row in the table.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// RowOption is used to provide options to NewRowWithOpts.
+type RowOption interface {
+	// set sets the provided option.
+	set(*Row)
+}
+
+// rowOption implements RowOption.
+type rowOption func(*Row)
+
+// set implements RowOption.set.
+func (ro rowOption) set(r *Row) {
+	ro(r)
+}
+
+// RowBorders configures the default per-side borders for all the cells in
+// the row.
+// This is a hierarchical option, it overrides the one provided at Content
+// level and can be overridden at the Cell level.
+func RowBorders(mask Border, opts ...cell.Option) RowOption {
+	return rowOption(func(r *Row) {
+		r.hierarchical.borders = &mask
+		r.hierarchical.borderOpts = opts
+	})
+}
+
+// Row is one row in the Content.
+type Row struct {
+	// cells are the cells that make up the row, in display order.
+	cells []*Cell
+
+	// hierarchical are the hierarchical options specified at the Row level.
+	hierarchical *hierarchicalOptions
+}
+
+// NewRow returns a new Row with the provided cells.
+func NewRow(cells ...*Cell) *Row {
+	return NewRowWithOpts(cells)
+}
+
+// NewRowWithOpts returns a new Row with the provided cells and options.
+func NewRowWithOpts(cells []*Cell, opts ...RowOption) *Row {
+	r := &Row{
+		cells:        cells,
+		hierarchical: &hierarchicalOptions{},
+	}
+	for _, opt := range opts {
+		opt.set(r)
+	}
+	return r
+}
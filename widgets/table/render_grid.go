@@ -0,0 +1,357 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// render_grid.go renders Content as a bordered grid, either using ASCII
+// characters (TextStyleASCII) or box-drawing characters (TextStyleUnicode).
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+)
+
+// renderGrid renders placed as a bordered TextStyleASCII or TextStyleUnicode
+// grid.
+func renderGrid(w io.Writer, rows, cols int, placed []*layoutCell, o *textRenderOptions) error {
+	widths := columnWidths(cols, placed, o.maxWidth)
+	heights := rowHeights(rows, placed, o.maxWidth)
+
+	bcells := make([]borderCell, len(placed))
+	for i, p := range placed {
+		bcells[i] = borderCell{row: p.row, col: p.col, rowSpan: p.rowSpan, colSpan: p.colSpan, borders: p.opts.borders}
+	}
+	owner := cellOwners(rows, cols, bcells)
+
+	blocks := make([][]string, len(placed))
+	for i, p := range placed {
+		blocks[i] = buildBlock(p, mergedWidth(p, widths), mergedHeight(p, heights))
+	}
+
+	heightsCum := make([]int, rows+1)
+	for r := 0; r < rows; r++ {
+		heightsCum[r+1] = heightsCum[r] + heights[r]
+	}
+
+	unicode := o.style == TextStyleUnicode
+	junctions := intersectionRunes(rows, cols, bcells, unicode)
+	for r := 0; r <= rows; r++ {
+		if err := writeBorderLine(w, r, rows, cols, owner, bcells, widths, junctions, unicode); err != nil {
+			return err
+		}
+		if r == rows {
+			break
+		}
+		for lineIdx := 0; lineIdx < heights[r]; lineIdx++ {
+			if err := writeContentLine(w, r, lineIdx, cols, owner, bcells, placed, blocks, heightsCum, widths, unicode, o.ansiColors); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergedWidth returns the total display width of a cell's content area,
+// including the interior column boundaries suppressed by its CellColSpan.
+func mergedWidth(p *layoutCell, widths []int) int {
+	w := 0
+	for c := p.col; c < p.col+p.colSpan; c++ {
+		w += widths[c]
+	}
+	return w + (p.colSpan - 1)
+}
+
+// mergedHeight returns the total number of display lines available to a
+// cell, spanning the rows its CellRowSpan covers.
+func mergedHeight(p *layoutCell, heights []int) int {
+	h := 0
+	for r := p.row; r < p.row+p.rowSpan; r++ {
+		h += heights[r]
+	}
+	return h
+}
+
+// columnWidths computes the display width of every column, wide enough for
+// the longest line of its non-spanning cells, growing the rightmost spanned
+// column when a spanning cell needs more room than its columns already
+// provide.
+func columnWidths(cols int, placed []*layoutCell, maxWidth int) []int {
+	widths := make([]int, cols)
+	for _, p := range placed {
+		if p.colSpan != 1 {
+			continue
+		}
+		if w := contentWidth(p, maxWidth); w > widths[p.col] {
+			widths[p.col] = w
+		}
+	}
+	for _, p := range placed {
+		if p.colSpan <= 1 {
+			continue
+		}
+		need := contentWidth(p, maxWidth)
+		have := 0
+		for c := p.col; c < p.col+p.colSpan; c++ {
+			have += widths[c]
+		}
+		have += p.colSpan - 1
+		if need > have {
+			widths[p.col+p.colSpan-1] += need - have
+		}
+	}
+	return widths
+}
+
+// rowHeights computes the display height of every row, analogous to
+// columnWidths.
+func rowHeights(rows int, placed []*layoutCell, maxWidth int) []int {
+	heights := make([]int, rows)
+	for _, p := range placed {
+		if p.rowSpan != 1 {
+			continue
+		}
+		if h := contentHeight(p, maxWidth); h > heights[p.row] {
+			heights[p.row] = h
+		}
+	}
+	for _, p := range placed {
+		if p.rowSpan <= 1 {
+			continue
+		}
+		need := contentHeight(p, maxWidth)
+		have := 0
+		for r := p.row; r < p.row+p.rowSpan; r++ {
+			have += heights[r]
+		}
+		if need > have {
+			heights[p.row+p.rowSpan-1] += need - have
+		}
+	}
+	return heights
+}
+
+// contentWidth returns the width, in runes, a cell's content needs,
+// including its horizontal padding on both sides.
+func contentWidth(p *layoutCell, maxWidth int) int {
+	max := 0
+	for _, line := range cellLines(cellText(p.cell), maxWidth, p.opts.wrapMode) {
+		if w := utf8.RuneCountInString(line); w > max {
+			max = w
+		}
+	}
+	return max + 2*p.opts.horizontalPadding
+}
+
+// contentHeight returns the number of display lines a cell's content needs,
+// including its vertical padding on both sides and the configured
+// CellHeight, if any.
+func contentHeight(p *layoutCell, maxWidth int) int {
+	h := len(cellLines(cellText(p.cell), maxWidth, p.opts.wrapMode))
+	if p.opts.height > h {
+		h = p.opts.height
+	}
+	return h + 2*p.opts.verticalPadding
+}
+
+// buildBlock renders a cell's content into exactly height lines of exactly
+// width runes each, applying its wrapping, padding and alignment.
+func buildBlock(p *layoutCell, width, height int) []string {
+	innerWidth := width - 2*p.opts.horizontalPadding
+	if innerWidth < 0 {
+		innerWidth = 0
+	}
+	lines := cellLines(cellText(p.cell), innerWidth, p.opts.wrapMode)
+
+	hPad := strings.Repeat(" ", p.opts.horizontalPadding)
+	content := make([]string, len(lines))
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) > innerWidth {
+			line = string([]rune(line)[:innerWidth])
+		}
+		content[i] = hPad + alignLine(line, innerWidth, p.opts.alignHorizontal) + hPad
+	}
+
+	blank := strings.Repeat(" ", width)
+	block := make([]string, height)
+	free := height - len(content)
+	if free < 0 {
+		content = content[:height]
+		free = 0
+	}
+
+	var before int
+	switch p.opts.alignVertical {
+	case align.VerticalBottom:
+		before = free
+	case align.VerticalMiddle:
+		before = free / 2
+	default:
+		before = 0
+	}
+
+	idx := 0
+	for ; idx < before; idx++ {
+		block[idx] = blank
+	}
+	for _, line := range content {
+		block[idx] = line
+		idx++
+	}
+	for ; idx < height; idx++ {
+		block[idx] = blank
+	}
+	return block
+}
+
+// writeBorderLine writes one horizontal gridline of the table, at the
+// boundary above content row r (r == rows for the final, bottom border).
+// junctions is the rows+1 x cols+1 grid of intersection runes computed by
+// intersectionRunes.
+func writeBorderLine(w io.Writer, r, rows, cols int, owner [][]int, bcells []borderCell, widths []int, junctions [][]rune, unicode bool) error {
+	var b strings.Builder
+	for c := 0; c <= cols; c++ {
+		b.WriteRune(junctions[r][c])
+
+		if c == cols {
+			break
+		}
+		if hEdge(owner, bcells, rows, r, c) {
+			b.WriteString(strings.Repeat(gridHorizontal(unicode), widths[c]))
+		} else {
+			b.WriteString(strings.Repeat(" ", widths[c]))
+		}
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeContentLine writes one line of cell content for grid row r.
+func writeContentLine(w io.Writer, r, lineIdx, cols int, owner [][]int, bcells []borderCell, placed []*layoutCell, blocks [][]string, heightsCum []int, widths []int, unicode, ansiColors bool) error {
+	var b strings.Builder
+	for c := 0; c < cols; {
+		if vEdge(owner, bcells, cols, r, c) {
+			b.WriteRune(gridVertical(unicode))
+		}
+		idx := owner[r][c]
+		if idx < 0 {
+			b.WriteString(strings.Repeat(" ", widths[c]))
+			c++
+			continue
+		}
+		p := placed[idx]
+		blockLine := heightsCum[r] - heightsCum[p.row] + lineIdx
+		text := blocks[idx][blockLine]
+		if ansiColors {
+			text = applyANSI(text, p.opts.cellOpts)
+		}
+		b.WriteString(text)
+		c += p.colSpan
+	}
+	if vEdge(owner, bcells, cols, r, cols) {
+		b.WriteRune(gridVertical(unicode))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// gridRune returns the rune to draw at a grid intersection with the given
+// 4-bit incident-lines mask (see junctionRunes), in the requested style.
+func gridRune(mask uint8, unicode bool) rune {
+	if unicode {
+		return junctionRune(mask)
+	}
+	switch {
+	case mask == 0:
+		return ' '
+	case mask == 0b0101: // up + down only
+		return '|'
+	case mask == 0b1010: // left + right only
+		return '-'
+	default:
+		return '+'
+	}
+}
+
+// gridHorizontal returns the rune used to fill horizontal gridlines.
+func gridHorizontal(unicode bool) string {
+	if unicode {
+		return "─"
+	}
+	return "-"
+}
+
+// gridVertical returns the rune used to draw vertical gridlines.
+func gridVertical(unicode bool) rune {
+	if unicode {
+		return '│'
+	}
+	return '|'
+}
+
+// applyANSI wraps text in the SGR escape sequence reproducing the
+// foreground and background colors configured by opts, if any.
+func applyANSI(text string, opts []cell.Option) string {
+	prefix := ansiPrefix(opts)
+	if prefix == "" {
+		return text
+	}
+	return prefix + text + "\x1b[0m"
+}
+
+// ansiPrefix returns the SGR escape sequence setting the foreground and
+// background colors configured by opts, or "" if neither is set.
+func ansiPrefix(opts []cell.Option) string {
+	o := cell.NewOptions(opts...)
+	var codes []string
+	if code, ok := ansiColorCode(38, o.FgColor); ok {
+		codes = append(codes, code)
+	}
+	if code, ok := ansiColorCode(48, o.BgColor); ok {
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// ansiPaletteSize is the number of indexes probed by ansiColorCode when
+// looking for the xterm 256-color index a cell.Color was built from.
+const ansiPaletteSize = 256
+
+// ansiColorCode returns the SGR color-setting code for c, using the 38/48
+// (foreground/background) base selected by base. cell.Color doesn't expose
+// its components, so rather than guess at unexported internals, this only
+// recognizes colors built through the public cell.ColorNumber constructor
+// (which covers both the named 16-color constants and the 256-color
+// palette); any other color, e.g. one set up as a true color, is left
+// unstyled.
+func ansiColorCode(base int, c cell.Color) (string, bool) {
+	if c == cell.ColorDefault {
+		return "", false
+	}
+	for n := 0; n < ansiPaletteSize; n++ {
+		if c == cell.ColorNumber(n) {
+			return fmt.Sprintf("%d;5;%d", base, n), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// render_markdown.go renders Content as a GitHub Flavored Markdown pipe
+// table.
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mum4k/termdash/align"
+)
+
+// renderMarkdown renders placed as a GFM pipe table. GFM has no concept of
+// row or column spans, so the content of a spanned cell is repeated in
+// every cell of the grid it covers. The first row is always treated as the
+// table header, since GFM requires one.
+func renderMarkdown(w io.Writer, rows, cols int, placed []*layoutCell) error {
+	grid := make([][]string, rows)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+	}
+
+	horizontals := make([]align.Horizontal, cols)
+	for _, p := range placed {
+		text := strings.ReplaceAll(cellText(p.cell), "\n", " ")
+		text = strings.ReplaceAll(text, "|", "\\|")
+		for r := p.row; r < p.row+p.rowSpan && r < rows; r++ {
+			for c := p.col; c < p.col+p.colSpan && c < cols; c++ {
+				grid[r][c] = text
+				if p.row == 0 {
+					horizontals[c] = p.opts.alignHorizontal
+				}
+			}
+		}
+	}
+
+	if err := writeMarkdownRow(w, grid[0]); err != nil {
+		return err
+	}
+	if err := writeMarkdownSeparator(w, horizontals); err != nil {
+		return err
+	}
+	for _, row := range grid[1:] {
+		if err := writeMarkdownRow(w, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdownRow writes one GFM pipe table row.
+func writeMarkdownRow(w io.Writer, cells []string) error {
+	_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	return err
+}
+
+// writeMarkdownSeparator writes the header separator row, e.g.
+// "| :--- | :---: | ---: |", encoding each column's alignment as the
+// position of its colons.
+func writeMarkdownSeparator(w io.Writer, horizontals []align.Horizontal) error {
+	cells := make([]string, len(horizontals))
+	for i, h := range horizontals {
+		switch h {
+		case align.HorizontalRight:
+			cells[i] = "---:"
+		case align.HorizontalCenter:
+			cells[i] = ":---:"
+		default:
+			cells[i] = ":---"
+		}
+	}
+	return writeMarkdownRow(w, cells)
+}
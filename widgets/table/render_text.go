@@ -0,0 +1,259 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// render_text.go implements an offline renderer that turns Content into
+// plain text, without needing a terminal.
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/internal/wrap"
+)
+
+// TextStyle selects the output format produced by Content.RenderText.
+type TextStyle int
+
+// String implements fmt.Stringer.
+func (s TextStyle) String() string {
+	if n, ok := textStyleNames[s]; ok {
+		return n
+	}
+	return "TextStyleUnknown"
+}
+
+// textStyleNames maps TextStyle values to human readable names.
+var textStyleNames = map[TextStyle]string{
+	TextStyleASCII:    "TextStyleASCII",
+	TextStyleUnicode:  "TextStyleUnicode",
+	TextStyleMarkdown: "TextStyleMarkdown",
+}
+
+const (
+	// TextStyleASCII draws gridlines using only ASCII characters, e.g.
+	// "+---+" and "|".
+	TextStyleASCII TextStyle = iota
+
+	// TextStyleUnicode draws gridlines using box-drawing characters, the
+	// same ones the widget itself draws on a terminal.
+	TextStyleUnicode
+
+	// TextStyleMarkdown renders a GitHub Flavored Markdown pipe table.
+	// Since GFM has no concept of row or column spans, the content of a
+	// spanned cell is repeated in every table cell the span covers.
+	TextStyleMarkdown
+)
+
+// TextRenderOption is used to provide options to Content.RenderText.
+type TextRenderOption interface {
+	// set sets the provided option.
+	set(*textRenderOptions)
+}
+
+// textRenderOption implements TextRenderOption.
+type textRenderOption func(*textRenderOptions)
+
+// set implements TextRenderOption.set.
+func (o textRenderOption) set(opts *textRenderOptions) {
+	o(opts)
+}
+
+// textRenderOptions stores the options for RenderText.
+type textRenderOptions struct {
+	style      TextStyle
+	maxWidth   int
+	ansiColors bool
+}
+
+// newTextRenderOptions returns textRenderOptions with the default values
+// set.
+func newTextRenderOptions() *textRenderOptions {
+	return &textRenderOptions{
+		style: TextStyleASCII,
+	}
+}
+
+// WithTextStyle sets the output format. Defaults to TextStyleASCII.
+func WithTextStyle(s TextStyle) TextRenderOption {
+	return textRenderOption(func(opts *textRenderOptions) {
+		opts.style = s
+	})
+}
+
+// WithMaxWidth caps the width of any single column's content to the
+// provided number of cells. A cell whose content exceeds this width is
+// wrapped if it has the CellWrapAtWords option set, or trimmed otherwise,
+// exactly like the on-screen widget. Defaults to no limit.
+func WithMaxWidth(cols int) TextRenderOption {
+	return textRenderOption(func(opts *textRenderOptions) {
+		opts.maxWidth = cols
+	})
+}
+
+// WithANSIColors instructs RenderText to wrap the rendered text of each
+// cell in ANSI escape sequences reproducing its configured cell.Option
+// colors. Only colors set through cell.ColorNumber or the named 16-color
+// constants are reproduced; other colors are left unstyled. Ignored under
+// TextStyleMarkdown, since GFM has no notion of inline terminal colors.
+// Defaults to off, i.e. plain text output.
+func WithANSIColors() TextRenderOption {
+	return textRenderOption(func(opts *textRenderOptions) {
+		opts.ansiColors = true
+	})
+}
+
+// RenderText writes Content to w as plain text, without needing a
+// terminal, e.g. for logging dashboards or pasting a table into a bug
+// report.
+func (c *Content) RenderText(w io.Writer, opts ...TextRenderOption) error {
+	o := newTextRenderOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	rows, cols, placed := layout(c)
+	if rows == 0 || cols == 0 {
+		return nil
+	}
+
+	if o.style == TextStyleMarkdown {
+		return renderMarkdown(w, rows, cols, placed)
+	}
+	return renderGrid(w, rows, cols, placed, o)
+}
+
+// layoutCell is one Cell placed at its resolved position in the logical
+// rows x cols grid, after expanding row and column spans.
+type layoutCell struct {
+	row, col         int
+	rowSpan, colSpan int
+	cell             *Cell
+	opts             *resolved
+}
+
+// layout places every Cell of every Row in c into a rows x cols grid, in
+// the same left-to-right, top-to-bottom order the widget draws them in,
+// skipping over the positions a previous row's CellRowSpan already claimed.
+func layout(c *Content) (rows, cols int, placed []*layoutCell) {
+	occupied := map[int]map[int]bool{}
+	for r, row := range c.rows {
+		col := 0
+		for _, cl := range row.cells {
+			for occupied[r][col] {
+				col++
+			}
+			placed = append(placed, &layoutCell{
+				row:     r,
+				col:     col,
+				rowSpan: cl.rowSpan,
+				colSpan: cl.colSpan,
+				cell:    cl,
+				opts:    resolveHierarchical(c.hierarchical, row.hierarchical, cl.hierarchical),
+			})
+			for rs := 0; rs < cl.rowSpan; rs++ {
+				if occupied[r+rs] == nil {
+					occupied[r+rs] = map[int]bool{}
+				}
+				for cs := 0; cs < cl.colSpan; cs++ {
+					occupied[r+rs][col+cs] = true
+				}
+			}
+			col += cl.colSpan
+			if col > cols {
+				cols = col
+			}
+		}
+	}
+	rows = len(c.rows)
+	return rows, cols, placed
+}
+
+// cellText returns the Cell's content as a single string, concatenating
+// the text of all of its Data in order.
+func cellText(c *Cell) string {
+	var b strings.Builder
+	for _, d := range c.data {
+		b.WriteString(d.text)
+	}
+	return b.String()
+}
+
+// cellLines splits text on its newlines and, if width is positive and a
+// line exceeds it, either wraps the line at word boundaries (when wrapMode
+// is wrap.AtWords) or trims it to width. Width and the amount trimmed are
+// both counted in runes, not bytes, so multi-byte characters are never cut
+// in half.
+func cellLines(text string, width int, wrapMode wrap.Mode) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		if width <= 0 || utf8.RuneCountInString(line) <= width {
+			out = append(out, line)
+			continue
+		}
+		if wrapMode == wrap.AtWords {
+			out = append(out, wrapAtWords(line, width)...)
+		} else {
+			out = append(out, string([]rune(line)[:width]))
+		}
+	}
+	return out
+}
+
+// wrapAtWords greedily packs words of s onto lines no wider than width,
+// measured in runes. A single word longer than width is placed on its own,
+// oversized line.
+func wrapAtWords(s string, width int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	cur := words[0]
+	curWidth := utf8.RuneCountInString(cur)
+	for _, word := range words[1:] {
+		wordWidth := utf8.RuneCountInString(word)
+		if curWidth+1+wordWidth > width {
+			lines = append(lines, cur)
+			cur = word
+			curWidth = wordWidth
+			continue
+		}
+		cur += " " + word
+		curWidth += 1 + wordWidth
+	}
+	return append(lines, cur)
+}
+
+// alignLine pads line to width according to h, assuming line is no wider
+// than width runes.
+func alignLine(line string, width int, h align.Horizontal) string {
+	pad := width - utf8.RuneCountInString(line)
+	if pad <= 0 {
+		return line
+	}
+	switch h {
+	case align.HorizontalRight:
+		return strings.Repeat(" ", pad) + line
+	case align.HorizontalCenter:
+		left := pad / 2
+		return strings.Repeat(" ", left) + line + strings.Repeat(" ", pad-left)
+	default:
+		return line + strings.Repeat(" ", pad)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTextASCII(t *testing.T) {
+	c := NewContent(ContentBorders(BorderAll))
+	c.AddRows(
+		NewRow(NewCell("a"), NewCell("b")),
+		NewRow(NewCellWithOpts([]*Data{NewData("X")}, CellColSpan(2))),
+	)
+
+	var buf strings.Builder
+	if err := c.RenderText(&buf, WithTextStyle(TextStyleASCII)); err != nil {
+		t.Fatalf("RenderText => unexpected error: %v", err)
+	}
+
+	// The second row's CellColSpan(2) cell is as wide as both of the first
+	// row's columns combined, so its own bottom border is one unbroken
+	// line instead of being interrupted by a gridline that would have
+	// fallen inside the span.
+	want := "" +
+		"+-+-+\n" +
+		"|a|b|\n" +
+		"+-+-+\n" +
+		"|X  |\n" +
+		"+---+\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderText(TextStyleASCII) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTextUnicode(t *testing.T) {
+	c := NewContent(ContentBorders(BorderAll))
+	c.AddRows(
+		NewRow(NewCell("a"), NewCell("b")),
+	)
+
+	var buf strings.Builder
+	if err := c.RenderText(&buf, WithTextStyle(TextStyleUnicode)); err != nil {
+		t.Fatalf("RenderText => unexpected error: %v", err)
+	}
+
+	want := "" +
+		"┌─┬─┐\n" +
+		"│a│b│\n" +
+		"└─┴─┘\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderText(TextStyleUnicode) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTextNoBorders(t *testing.T) {
+	c := NewContent()
+	c.AddRows(
+		NewRow(NewCell("a"), NewCell("b")),
+	)
+
+	var buf strings.Builder
+	if err := c.RenderText(&buf, WithTextStyle(TextStyleASCII)); err != nil {
+		t.Fatalf("RenderText => unexpected error: %v", err)
+	}
+
+	// With no CellBorders/RowBorders/ContentBorders configured anywhere,
+	// every gridline is absent, but the grid renderer still prints a
+	// border line above and below the content row, just a blank one.
+	want := "     \nab\n     \n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderText(TextStyleASCII, no borders) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTextMarkdown(t *testing.T) {
+	c := NewContent()
+	c.AddRows(
+		NewRow(NewCell("Name"), NewCell("A | B")),
+		NewRow(NewCellWithOpts([]*Data{NewData("spans both")}, CellColSpan(2))),
+	)
+
+	var buf strings.Builder
+	if err := c.RenderText(&buf, WithTextStyle(TextStyleMarkdown)); err != nil {
+		t.Fatalf("RenderText => unexpected error: %v", err)
+	}
+
+	want := "" +
+		"| Name | A \\| B |\n" +
+		"| :--- | :--- |\n" +
+		"| spans both | spans both |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderText(TextStyleMarkdown) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTextEmptyContent(t *testing.T) {
+	c := NewContent()
+
+	var buf strings.Builder
+	if err := c.RenderText(&buf, WithTextStyle(TextStyleASCII)); err != nil {
+		t.Fatalf("RenderText => unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "" {
+		t.Errorf("RenderText on empty Content = %q, want empty", got)
+	}
+}